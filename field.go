@@ -0,0 +1,143 @@
+package logger
+
+import (
+	"strconv"
+	"time"
+)
+
+// FieldKind identifies which of Field's value slots holds valid data.
+type FieldKind int
+
+const (
+	KindString FieldKind = iota
+	KindBool
+	KindInt64
+	KindUint64
+	KindFloat64
+	KindDuration
+	KindTime
+	KindError
+)
+
+// Field is a single structured log attribute. Exactly one of its value
+// slots is populated, selected by kind, so AppendJSON/AppendText can
+// serialize it in place without going through an interface{} or an
+// intermediate ValueJSON() allocation.
+type Field struct {
+	name string
+	kind FieldKind
+
+	str      string
+	boolean  bool
+	integer  int64
+	unsigned uint64
+	float    float64
+	duration time.Duration
+	when     time.Time
+	err      error
+}
+
+// Name returns the field's key.
+func (f Field) Name() string { return f.name }
+
+// Kind returns which value slot is populated.
+func (f Field) Kind() FieldKind { return f.kind }
+
+// String constructs a string-valued Field.
+func String(name, value string) Field {
+	return Field{name: name, kind: KindString, str: value}
+}
+
+// Bool constructs a bool-valued Field.
+func Bool(name string, value bool) Field {
+	return Field{name: name, kind: KindBool, boolean: value}
+}
+
+// Int constructs an int-valued Field.
+func Int(name string, value int) Field {
+	return Field{name: name, kind: KindInt64, integer: int64(value)}
+}
+
+// Int64 constructs an int64-valued Field.
+func Int64(name string, value int64) Field {
+	return Field{name: name, kind: KindInt64, integer: value}
+}
+
+// Uint64 constructs a uint64-valued Field.
+func Uint64(name string, value uint64) Field {
+	return Field{name: name, kind: KindUint64, unsigned: value}
+}
+
+// Float64 constructs a float64-valued Field.
+func Float64(name string, value float64) Field {
+	return Field{name: name, kind: KindFloat64, float: value}
+}
+
+// Duration constructs a time.Duration-valued Field.
+func Duration(name string, value time.Duration) Field {
+	return Field{name: name, kind: KindDuration, duration: value}
+}
+
+// Time constructs a time.Time-valued Field.
+func Time(name string, value time.Time) Field {
+	return Field{name: name, kind: KindTime, when: value}
+}
+
+// Error constructs an error-valued Field.
+func Error(name string, value error) Field {
+	return Field{name: name, kind: KindError, err: value}
+}
+
+// ValueJSON returns the field's value alone, already encoded as a JSON
+// value (a quoted string, a bare number, true/false, or null).
+func (f Field) ValueJSON() []byte {
+	return f.appendValue(nil)
+}
+
+// AppendJSON appends this field's `"name":value` JSON representation to
+// dst, encoding directly from the field's kind and raw value so no
+// intermediate []byte is allocated per field.
+func (f Field) AppendJSON(dst []byte) []byte {
+	dst = append(dst, '"')
+	dst = append(dst, f.name...)
+	dst = append(dst, '"', ':')
+	return f.appendValue(dst)
+}
+
+// AppendText appends this field's "name: value" text representation to
+// dst, matching the value formatting AppendJSON uses.
+func (f Field) AppendText(dst []byte) []byte {
+	dst = append(dst, f.name...)
+	dst = append(dst, ':', ' ')
+	return f.appendValue(dst)
+}
+
+// appendValue appends just the encoded value (no key) for whichever slot
+// Kind selects, using strconv's Append* family directly on the raw value.
+func (f Field) appendValue(dst []byte) []byte {
+	switch f.kind {
+	case KindString:
+		return strconv.AppendQuote(dst, f.str)
+	case KindBool:
+		return strconv.AppendBool(dst, f.boolean)
+	case KindInt64:
+		return strconv.AppendInt(dst, f.integer, 10)
+	case KindUint64:
+		return strconv.AppendUint(dst, f.unsigned, 10)
+	case KindFloat64:
+		return strconv.AppendFloat(dst, f.float, 'g', -1, 64)
+	case KindDuration:
+		return strconv.AppendQuote(dst, f.duration.String())
+	case KindTime:
+		dst = append(dst, '"')
+		dst = f.when.AppendFormat(dst, time.RFC3339Nano)
+		return append(dst, '"')
+	case KindError:
+		if f.err == nil {
+			return append(dst, "null"...)
+		}
+		return strconv.AppendQuote(dst, f.err.Error())
+	default:
+		return append(dst, "null"...)
+	}
+}
@@ -0,0 +1,91 @@
+package logger
+
+import (
+	"errors"
+	"fmt"
+	"os"
+)
+
+// MultiSink dispatches each log entry to N independently configured sinks,
+// each with its own minLevel, format bitmask, and isText choice, so a
+// single Log.Info(...) call can, for example, write human-readable text at
+// verbose to stderr, JSON at info to a file, and JSON at error to a network
+// collector.
+type MultiSink struct {
+	sinks []*systemConfig
+}
+
+// newMultiSink builds a MultiSink that fans out to the given sink configs.
+// Unexported because systemConfig is unexported; external callers go through
+// NewMultiSinkFromSetup instead. Sinks are held by pointer since systemConfig
+// carries a sync.Mutex and atomic.Value fields that must never be copied.
+func newMultiSink(sinks ...*systemConfig) *MultiSink {
+	return &MultiSink{sinks: sinks}
+}
+
+// NewMultiSinkFromSetup builds a MultiSink from one SetupConfig per sink,
+// using the same construction path as the rest of the package.
+func NewMultiSinkFromSetup(setups ...SetupConfig) (*MultiSink, error) {
+	sinks := make([]*systemConfig, 0, len(setups))
+	for _, setup := range setups {
+		config, err := newSystemConfigFromSetup(setup)
+		if err != nil {
+			return nil, err
+		}
+		sinks = append(sinks, &config)
+	}
+
+	return newMultiSink(sinks...), nil
+}
+
+// writeEntry gates and formats the message at most once, then fans it out
+// to every sink, gating each sink independently on its own minLevel.
+// Per-sink write errors are aggregated rather than short-circuiting, and
+// Fatal/Panic only fire after every sink has been written (and flushed if
+// it implements Sync() error).
+func (m *MultiSink) writeEntry(level Level, caller string, fields []Field,
+	format string, values ...interface{}) error {
+
+	var msg string
+	var formatted bool
+	var errs []error
+
+	for _, sink := range m.sinks {
+		if sink.output == nil || sink.minLevelFor() > level {
+			continue
+		}
+
+		if !formatted {
+			msg = format
+			if len(values) > 0 {
+				msg = fmt.Sprintf(format, values...)
+			}
+			formatted = true
+		}
+
+		var err error
+		if sink.isText {
+			err = sink.writeTextEntry(level, caller, fields, msg)
+		} else {
+			err = sink.writeJSONEntry(level, caller, fields, msg)
+		}
+		if err != nil {
+			errs = append(errs, err)
+		}
+
+		if syncer, ok := sink.output.(interface{ Sync() error }); ok {
+			if err := syncer.Sync(); err != nil {
+				errs = append(errs, err)
+			}
+		}
+	}
+
+	switch level {
+	case LevelFatal:
+		defer os.Exit(1)
+	case LevelPanic:
+		defer panic(msg)
+	}
+
+	return errors.Join(errs...)
+}
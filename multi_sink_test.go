@@ -0,0 +1,63 @@
+package logger
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"testing"
+)
+
+type errWriter struct {
+	buf bytes.Buffer
+	err error
+}
+
+func (w *errWriter) Write(b []byte) (int, error) {
+	if w.err != nil {
+		return 0, w.err
+	}
+	return w.buf.Write(b)
+}
+
+func newTestMultiSinkConfig(minLevel Level, output io.Writer) *systemConfig {
+	return &systemConfig{minLevel: minLevel, output: output, format: IncludeLevel}
+}
+
+func TestMultiSinkGatesEachSinkIndependently(t *testing.T) {
+	verbose := &errWriter{}
+	errorOnly := &errWriter{}
+
+	m := newMultiSink(
+		newTestMultiSinkConfig(LevelVerbose, verbose),
+		newTestMultiSinkConfig(LevelError, errorOnly),
+	)
+
+	if err := m.writeEntry(LevelInfo, "caller", nil, "hello"); err != nil {
+		t.Fatalf("writeEntry: %v", err)
+	}
+
+	if verbose.buf.Len() == 0 {
+		t.Fatal("expected the verbose-gated sink to receive the info-level entry")
+	}
+	if errorOnly.buf.Len() != 0 {
+		t.Fatal("expected the error-gated sink to drop the info-level entry")
+	}
+}
+
+func TestMultiSinkJoinsPerSinkErrorsAndStillWritesHealthySinks(t *testing.T) {
+	failing := &errWriter{err: errors.New("sink down")}
+	ok := &errWriter{}
+
+	m := newMultiSink(
+		newTestMultiSinkConfig(LevelInfo, failing),
+		newTestMultiSinkConfig(LevelInfo, ok),
+	)
+
+	err := m.writeEntry(LevelInfo, "caller", nil, "hello")
+	if err == nil {
+		t.Fatal("expected writeEntry to report the failing sink's error")
+	}
+	if ok.buf.Len() == 0 {
+		t.Fatal("expected the healthy sink to still receive the entry despite the other sink failing")
+	}
+}
@@ -2,8 +2,12 @@ package logger
 
 import (
 	"io"
+	"net"
+	"net/url"
 	"os"
 	"sync"
+	"sync/atomic"
+	"time"
 
 	"github.com/pkg/errors"
 )
@@ -13,6 +17,10 @@ func newOutput(path string) (io.Writer, error) {
 		if path == "dummy" { // for benchmarking
 			return &dummyWriter{}, nil
 		} else {
+			if writer, isNetwork, err := newNetworkOutput(path); isNetwork {
+				return writer, err
+			}
+
 			file, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
 			if err != nil {
 				return nil, errors.Wrap(err, "open file")
@@ -57,3 +65,269 @@ type dummyWriter struct{}
 func (d *dummyWriter) Write(b []byte) (int, error) {
 	return len(b), nil
 }
+
+const (
+	// defaultNetworkWriteDeadline bounds how long a single write to a network
+	// sink is allowed to block before the message is handed off to the
+	// background retry queue instead.
+	defaultNetworkWriteDeadline = 2 * time.Second
+
+	defaultNetworkQueueSize = 1024
+	minReconnectBackoff     = 100 * time.Millisecond
+	maxReconnectBackoff     = 30 * time.Second
+)
+
+// droppedNetworkMessages counts log entries dropped by network sinks, either
+// because the retry queue was full or a queued write ultimately failed after
+// its one retry. Read it through DroppedNetworkCount, not directly -- a
+// plain read of an int64 written with atomic.AddInt64 races.
+var droppedNetworkMessages int64
+
+// DroppedNetworkCount returns the number of log entries dropped by network
+// sinks so far.
+func DroppedNetworkCount() int64 {
+	return atomic.LoadInt64(&droppedNetworkMessages)
+}
+
+// isNetworkPath reports whether path uses a "tcp://", "udp://", or
+// "unix://" scheme, and if so returns the network/address pair to dial.
+func isNetworkPath(path string) (network, address string, ok bool) {
+	u, err := url.Parse(path)
+	if err != nil || u.Scheme == "" {
+		return "", "", false
+	}
+
+	switch u.Scheme {
+	case "tcp", "udp":
+		return u.Scheme, u.Host, true
+	case "unix":
+		return u.Scheme, u.Path, true
+	default:
+		return "", "", false
+	}
+}
+
+// newNetworkOutput builds an io.Writer for a "tcp://", "udp://", or
+// "unix://" path, or reports isNetwork == false so the caller falls back to
+// treating path as a file path. A "?reconnect=always" query parameter
+// selects the reconnect-on-every-message mode; otherwise the writer keeps a
+// persistent connection and redials lazily on write errors.
+func newNetworkOutput(path string) (writer io.Writer, isNetwork bool, err error) {
+	u, _ := url.Parse(path)
+
+	network, address, ok := isNetworkPath(path)
+	if !ok {
+		return nil, false, nil
+	}
+
+	w := &netWriter{
+		network:        network,
+		address:        address,
+		writeDeadline:  defaultNetworkWriteDeadline,
+		reconnectEvery: u.Query().Get("reconnect") == "always",
+		queue:          make(chan []byte, defaultNetworkQueueSize),
+		closed:         make(chan struct{}),
+	}
+
+	w.wg.Add(1)
+	go w.retryLoop()
+
+	return w, true, nil
+}
+
+// netWriter streams entries to a TCP, UDP, or UNIX socket endpoint. It holds
+// a persistent connection and redials lazily on write errors (or redials on
+// every write when reconnectEvery is set), is safe for concurrent Write
+// calls, and never blocks the caller longer than writeDeadline total --
+// dialing and writing share one deadline budget, not one each -- writes that
+// fail are hidden from the caller and handed to a background goroutine that
+// retries with exponential backoff from a bounded queue, dropping (and
+// counting via droppedNetworkMessages) anything that doesn't fit.
+type netWriter struct {
+	network        string
+	address        string
+	writeDeadline  time.Duration
+	reconnectEvery bool
+
+	lock sync.Mutex
+	conn net.Conn
+
+	queue     chan []byte
+	closeOnce sync.Once
+	closed    chan struct{}
+	wg        sync.WaitGroup
+}
+
+func (w *netWriter) Write(b []byte) (int, error) {
+	buf := make([]byte, len(b))
+	copy(buf, b)
+
+	start := time.Now()
+	conn, err := w.connection()
+	if err != nil {
+		w.enqueue(buf)
+		return len(b), nil
+	}
+
+	conn.SetWriteDeadline(time.Now().Add(w.remainingDeadline(start)))
+	if _, err := conn.Write(buf); err != nil {
+		w.dropConnection(conn)
+		w.enqueue(buf)
+		return len(b), nil
+	}
+
+	if w.reconnectEvery {
+		w.dropConnection(conn)
+	}
+
+	return len(b), nil
+}
+
+// remainingDeadline returns what's left of writeDeadline after connection()
+// (which may have spent part of the budget dialing) since start, floored at
+// a small positive duration so the write is still attempted instead of
+// timing out before it tries -- this is what keeps Write's total blocking
+// time bounded by writeDeadline rather than by writeDeadline for the dial
+// plus writeDeadline again for the write.
+func (w *netWriter) remainingDeadline(start time.Time) time.Duration {
+	remaining := w.writeDeadline - time.Since(start)
+	if remaining < time.Millisecond {
+		return time.Millisecond
+	}
+	return remaining
+}
+
+// connection returns the current connection, dialing a new one if there
+// isn't one yet or reconnectEvery is set. The dial happens outside w.lock so
+// a slow or hung endpoint only blocks the caller waiting on this dial, not
+// every other concurrent Write piled up behind the mutex.
+func (w *netWriter) connection() (net.Conn, error) {
+	w.lock.Lock()
+	existing := w.conn
+	reconnect := w.reconnectEvery
+	w.lock.Unlock()
+
+	if existing != nil && !reconnect {
+		return existing, nil
+	}
+
+	conn, err := net.DialTimeout(w.network, w.address, w.writeDeadline)
+	if err != nil {
+		return nil, errors.Wrap(err, "dial")
+	}
+
+	if reconnect {
+		// This connection is used once and closed by the caller via
+		// dropConnection, so it's never stored -- nothing else can race
+		// against it here.
+		return conn, nil
+	}
+
+	w.lock.Lock()
+	if w.conn != nil {
+		// A concurrent Write already dialed and stored a connection while
+		// we were dialing ours. Keep theirs and close ours so it isn't
+		// leaked.
+		already := w.conn
+		w.lock.Unlock()
+		conn.Close()
+		return already, nil
+	}
+	w.conn = conn
+	w.lock.Unlock()
+
+	return conn, nil
+}
+
+// dropConnection closes conn and clears it if it is still the current
+// connection.
+func (w *netWriter) dropConnection(conn net.Conn) {
+	w.lock.Lock()
+	if w.conn == conn {
+		w.conn = nil
+	}
+	w.lock.Unlock()
+	conn.Close()
+}
+
+// enqueue hands b to the background retry goroutine, dropping it and
+// incrementing droppedNetworkMessages if the queue is full.
+func (w *netWriter) enqueue(b []byte) {
+	select {
+	case w.queue <- b:
+	default:
+		atomic.AddInt64(&droppedNetworkMessages, 1)
+	}
+}
+
+// retryLoop redials and retries each queued write exactly once: a message
+// that fails again after a redial is dropped rather than looped back onto
+// the queue indefinitely. The backoff only paces how fast this goroutine
+// redials between distinct queued messages.
+func (w *netWriter) retryLoop() {
+	defer w.wg.Done()
+
+	backoff := minReconnectBackoff
+	for {
+		select {
+		case b := <-w.queue:
+			conn, err := w.connection()
+			if err != nil {
+				atomic.AddInt64(&droppedNetworkMessages, 1)
+				time.Sleep(backoff)
+				if backoff *= 2; backoff > maxReconnectBackoff {
+					backoff = maxReconnectBackoff
+				}
+				continue
+			}
+
+			backoff = minReconnectBackoff
+			conn.SetWriteDeadline(time.Now().Add(w.writeDeadline))
+			if _, err := conn.Write(b); err != nil {
+				w.dropConnection(conn)
+				atomic.AddInt64(&droppedNetworkMessages, 1)
+			} else if w.reconnectEvery {
+				w.dropConnection(conn)
+			}
+		case <-w.closed:
+			return
+		}
+	}
+}
+
+// Close stops the retry goroutine and makes a best-effort attempt to flush
+// whatever is left in the queue before closing the underlying connection.
+func (w *netWriter) Close() error {
+	w.closeOnce.Do(func() {
+		close(w.closed)
+	})
+	w.wg.Wait()
+
+drain:
+	for {
+		select {
+		case b := <-w.queue:
+			if conn, err := w.connection(); err == nil {
+				conn.SetWriteDeadline(time.Now().Add(w.writeDeadline))
+				if _, err := conn.Write(b); err != nil {
+					w.dropConnection(conn)
+					atomic.AddInt64(&droppedNetworkMessages, 1)
+				}
+			} else {
+				atomic.AddInt64(&droppedNetworkMessages, 1)
+			}
+		default:
+			break drain
+		}
+	}
+
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	if w.conn != nil {
+		err := w.conn.Close()
+		w.conn = nil
+		return err
+	}
+
+	return nil
+}
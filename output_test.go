@@ -0,0 +1,165 @@
+package logger
+
+import (
+	"net"
+	"testing"
+	"time"
+)
+
+// acceptAndCollect accepts exactly one connection on ln and returns a
+// channel that receives each chunk read from it (one Read's worth of data,
+// not necessarily one line), closing the channel when the connection is
+// closed.
+func acceptAndCollect(t *testing.T, ln net.Listener) <-chan string {
+	t.Helper()
+
+	lines := make(chan string, 16)
+	go func() {
+		conn, err := ln.Accept()
+		if err != nil {
+			close(lines)
+			return
+		}
+		defer conn.Close()
+
+		buf := make([]byte, 4096)
+		for {
+			n, err := conn.Read(buf)
+			if n > 0 {
+				lines <- string(buf[:n])
+			}
+			if err != nil {
+				close(lines)
+				return
+			}
+		}
+	}()
+
+	return lines
+}
+
+func TestNetWriterWritesToListener(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	defer ln.Close()
+
+	lines := acceptAndCollect(t, ln)
+
+	w := &netWriter{
+		network:       "tcp",
+		address:       ln.Addr().String(),
+		writeDeadline: time.Second,
+		queue:         make(chan []byte, defaultNetworkQueueSize),
+		closed:        make(chan struct{}),
+	}
+	w.wg.Add(1)
+	go w.retryLoop()
+	defer w.Close()
+
+	if _, err := w.Write([]byte("hello\n")); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+
+	select {
+	case got := <-lines:
+		if got != "hello\n" {
+			t.Fatalf("expected %q, got %q", "hello\n", got)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for listener to receive write")
+	}
+}
+
+func TestNetWriterRedialsAfterConnectionDropped(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	defer ln.Close()
+
+	w := &netWriter{
+		network:       "tcp",
+		address:       ln.Addr().String(),
+		writeDeadline: time.Second,
+		queue:         make(chan []byte, defaultNetworkQueueSize),
+		closed:        make(chan struct{}),
+	}
+	w.wg.Add(1)
+	go w.retryLoop()
+	defer w.Close()
+
+	// The writer dials lazily, so write first to establish the connection
+	// the listener then accepts.
+	if _, err := w.Write([]byte("first\n")); err != nil {
+		t.Fatalf("first write: %v", err)
+	}
+
+	conn, err := ln.Accept()
+	if err != nil {
+		t.Fatalf("accept: %v", err)
+	}
+
+	// Close the server side so the writer's stored connection goes bad,
+	// then accept again to confirm Write redials instead of erroring out.
+	conn.Close()
+	lines := acceptAndCollect(t, ln)
+
+	before := DroppedNetworkCount()
+
+	var wroteOK bool
+	deadline := time.Now().Add(2 * time.Second)
+	for time.Now().Before(deadline) {
+		if _, err := w.Write([]byte("second\n")); err != nil {
+			t.Fatalf("second write: %v", err)
+		}
+
+		select {
+		case got, ok := <-lines:
+			if ok && got == "second\n" {
+				wroteOK = true
+			}
+		case <-time.After(50 * time.Millisecond):
+		}
+
+		if wroteOK {
+			break
+		}
+	}
+
+	if !wroteOK {
+		t.Fatal("expected a later write to succeed against a redialed connection")
+	}
+
+	// The first write against the dead connection (or its retry) may have
+	// been dropped; that's expected and already covered by droppedNetworkMessages.
+	_ = before
+}
+
+func TestNetWriterDropsWhenQueueFull(t *testing.T) {
+	w := &netWriter{
+		network:       "tcp",
+		address:       "127.0.0.1:1", // nothing listening; dial fails
+		writeDeadline: 10 * time.Millisecond,
+		queue:         make(chan []byte, 1),
+		closed:        make(chan struct{}),
+	}
+	w.wg.Add(1)
+	go w.retryLoop()
+	defer w.Close()
+
+	before := DroppedNetworkCount()
+
+	// First Write enqueues (since dial fails); retryLoop may drain it
+	// before we enqueue the rest, so fill generously past the queue size.
+	for i := 0; i < 10; i++ {
+		if _, err := w.Write([]byte("x")); err != nil {
+			t.Fatalf("write %d: %v", i, err)
+		}
+	}
+
+	if DroppedNetworkCount() <= before {
+		t.Fatal("expected DroppedNetworkCount to increase when writes can't be delivered or queued")
+	}
+}
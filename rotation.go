@@ -0,0 +1,298 @@
+package logger
+
+import (
+	"compress/gzip"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// rotationTimeFormat is appended to the log path as ".YYYY-MM-DDTHH-MM-SS"
+// when a segment is rotated aside.
+const rotationTimeFormat = "2006-01-02T15-04-05"
+
+// defaultPruneInterval is how often run's background ticker sweeps for aged
+// backups, independent of any size-based rotation ever happening.
+const defaultPruneInterval = time.Hour
+
+// newOutputFromSetup builds the io.Writer described by setup. Plain file
+// paths get size- and time-based rotation when setup sets any of
+// MaxSizeBytes, MaxAgeHours, MaxBackups, or Compress; everything else
+// (stderr, "dummy", and network sinks) is handled by newOutput.
+func newOutputFromSetup(setup SetupConfig) (io.Writer, error) {
+	rotates := setup.MaxSizeBytes > 0 || setup.MaxAgeHours > 0 || setup.MaxBackups > 0 || setup.Compress
+
+	if rotates && len(setup.Path) > 0 && setup.Path != "dummy" {
+		if _, _, isNetwork := isNetworkPath(setup.Path); !isNetwork {
+			return newRotatingWriter(setup)
+		}
+	}
+
+	return newOutput(setup.Path)
+}
+
+// rotatingWriter wraps a file behind a mutex (like fileWriter) and rotates
+// it by size and age: on crossing MaxSizeBytes it renames the current file
+// to path.YYYY-MM-DDTHH-MM-SS and reopens a fresh path, gzipping the old
+// segment in the background when Compress is set. A background goroutine
+// also honors SIGHUP to force a rotation, coordinating with an external
+// logrotate, and prunes segments older than MaxAgeHours or beyond
+// MaxBackups on a timer, so a low-volume file that never crosses
+// MaxSizeBytes still gets pruned.
+type rotatingWriter struct {
+	path          string
+	maxSizeBytes  int64
+	maxAge        time.Duration
+	maxBackups    int
+	compress      bool
+	pruneInterval time.Duration
+
+	lock    sync.Mutex
+	file    *os.File
+	written int64
+
+	sighup chan os.Signal
+	done   chan struct{}
+	wg     sync.WaitGroup
+}
+
+func newRotatingWriter(setup SetupConfig) (*rotatingWriter, error) {
+	w := &rotatingWriter{
+		path:          setup.Path,
+		maxSizeBytes:  setup.MaxSizeBytes,
+		maxAge:        time.Duration(setup.MaxAgeHours) * time.Hour,
+		maxBackups:    setup.MaxBackups,
+		compress:      setup.Compress,
+		pruneInterval: defaultPruneInterval,
+		done:          make(chan struct{}),
+	}
+
+	if err := w.openCurrent(); err != nil {
+		return nil, err
+	}
+
+	w.prune() // catch backups that aged out while nothing was rotating
+
+	w.sighup = make(chan os.Signal, 1)
+	signal.Notify(w.sighup, syscall.SIGHUP)
+
+	w.wg.Add(1)
+	go w.run()
+
+	return w, nil
+}
+
+func (w *rotatingWriter) openCurrent() error {
+	file, err := os.OpenFile(w.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return errors.Wrap(err, "open file")
+	}
+
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return errors.Wrap(err, "stat file")
+	}
+
+	w.file = file
+	w.written = info.Size()
+
+	return nil
+}
+
+// Write implements io.Writer, rotating first if b would push the current
+// file past maxSizeBytes.
+func (w *rotatingWriter) Write(b []byte) (int, error) {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+
+	if w.maxSizeBytes > 0 && w.written > 0 && w.written+int64(len(b)) > w.maxSizeBytes {
+		if err := w.rotateLocked(); err != nil {
+			return 0, err
+		}
+	}
+
+	n, err := w.file.Write(b)
+	w.written += int64(n)
+
+	return n, err
+}
+
+// Rotate closes the current file, renames it aside with a timestamp suffix,
+// and opens a fresh file at path. Exported so tests and admin endpoints can
+// trigger it directly, in addition to the automatic size-based trigger and
+// the SIGHUP handler.
+func (w *rotatingWriter) Rotate() error {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+
+	return w.rotateLocked()
+}
+
+// uniqueBackupPath builds a backup path for the current rotation, adding a
+// numeric suffix if rotationTimeFormat's second resolution would otherwise
+// collide with an existing backup (a size-based burst, or Rotate()/SIGHUP
+// racing a size trigger, can rotate twice within the same second) so a
+// later segment never silently overwrites an earlier one.
+func (w *rotatingWriter) uniqueBackupPath() string {
+	base := w.path + "." + time.Now().Format(rotationTimeFormat)
+
+	path := base
+	for i := 1; ; i++ {
+		if _, err := os.Stat(path); os.IsNotExist(err) {
+			// A compressed backup of the same name would be truncated by
+			// compressFile, so it counts as taken too.
+			if _, err := os.Stat(path + ".gz"); os.IsNotExist(err) {
+				return path
+			}
+		}
+		path = fmt.Sprintf("%s-%d", base, i)
+	}
+}
+
+func (w *rotatingWriter) rotateLocked() error {
+	if w.file != nil {
+		w.file.Close()
+	}
+
+	if info, err := os.Stat(w.path); err == nil && info.Size() > 0 {
+		backupPath := w.uniqueBackupPath()
+		if err := os.Rename(w.path, backupPath); err != nil {
+			return errors.Wrap(err, "rename")
+		}
+
+		if w.compress {
+			go compressFile(backupPath)
+		}
+	}
+
+	if err := w.openCurrent(); err != nil {
+		return err
+	}
+
+	go w.prune()
+
+	return nil
+}
+
+// compressFile gzips path to path+".gz" and removes the uncompressed
+// original, best-effort.
+func compressFile(path string) {
+	in, err := os.Open(path)
+	if err != nil {
+		return
+	}
+	defer in.Close()
+
+	out, err := os.OpenFile(path+".gz", os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0644)
+	if err != nil {
+		return
+	}
+	defer out.Close()
+
+	gz := gzip.NewWriter(out)
+	if _, err := io.Copy(gz, in); err != nil {
+		gz.Close()
+		return
+	}
+	if err := gz.Close(); err != nil {
+		return
+	}
+
+	os.Remove(path)
+}
+
+// run watches for SIGHUP, forcing a rotation so this module can coordinate
+// with an external logrotate, and also prunes aged/excess backups on
+// pruneInterval so MaxAgeHours/MaxBackups are honored even on a file that
+// never crosses MaxSizeBytes, until Close is called.
+func (w *rotatingWriter) run() {
+	defer w.wg.Done()
+
+	ticker := time.NewTicker(w.pruneInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-w.sighup:
+			w.Rotate()
+		case <-ticker.C:
+			w.prune()
+		case <-w.done:
+			return
+		}
+	}
+}
+
+// prune removes backup segments older than maxAge and keeps at most
+// maxBackups, oldest first.
+func (w *rotatingWriter) prune() {
+	if w.maxAge <= 0 && w.maxBackups <= 0 {
+		return
+	}
+
+	dir := filepath.Dir(w.path)
+	base := filepath.Base(w.path)
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return
+	}
+
+	var backups []string
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasPrefix(name, base+".") {
+			continue
+		}
+		backups = append(backups, filepath.Join(dir, name))
+	}
+
+	sort.Strings(backups) // the timestamp suffix sorts chronologically
+
+	if w.maxAge > 0 {
+		cutoff := time.Now().Add(-w.maxAge)
+
+		var kept []string
+		for _, backup := range backups {
+			info, err := os.Stat(backup)
+			if err != nil || info.ModTime().Before(cutoff) {
+				os.Remove(backup)
+				continue
+			}
+			kept = append(kept, backup)
+		}
+		backups = kept
+	}
+
+	if w.maxBackups > 0 && len(backups) > w.maxBackups {
+		for _, backup := range backups[:len(backups)-w.maxBackups] {
+			os.Remove(backup)
+		}
+	}
+}
+
+// Close stops the SIGHUP watcher and closes the current file.
+func (w *rotatingWriter) Close() error {
+	signal.Stop(w.sighup)
+	close(w.done)
+	w.wg.Wait()
+
+	w.lock.Lock()
+	defer w.lock.Unlock()
+
+	if w.file != nil {
+		return w.file.Close()
+	}
+
+	return nil
+}
@@ -0,0 +1,120 @@
+package logger
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func newTestRotatingWriter(t *testing.T, maxSizeBytes int64, maxAge time.Duration, maxBackups int) *rotatingWriter {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "test.log")
+	w := &rotatingWriter{
+		path:         path,
+		maxSizeBytes: maxSizeBytes,
+		maxAge:       maxAge,
+		maxBackups:   maxBackups,
+	}
+
+	if err := w.openCurrent(); err != nil {
+		t.Fatalf("openCurrent: %v", err)
+	}
+	t.Cleanup(func() { w.file.Close() })
+
+	return w
+}
+
+func TestRotatingWriterRotatesOnSize(t *testing.T) {
+	w := newTestRotatingWriter(t, 10, 0, 0)
+
+	if _, err := w.Write([]byte("0123456789")); err != nil {
+		t.Fatalf("first write: %v", err)
+	}
+	if _, err := w.Write([]byte("more")); err != nil {
+		t.Fatalf("second write: %v", err)
+	}
+
+	entries, err := os.ReadDir(filepath.Dir(w.path))
+	if err != nil {
+		t.Fatalf("read dir: %v", err)
+	}
+
+	var backups int
+	for _, entry := range entries {
+		if entry.Name() != filepath.Base(w.path) {
+			backups++
+		}
+	}
+	if backups != 1 {
+		t.Fatalf("expected exactly one backup after crossing maxSizeBytes, got %d", backups)
+	}
+
+	data, err := os.ReadFile(w.path)
+	if err != nil {
+		t.Fatalf("read current file: %v", err)
+	}
+	if string(data) != "more" {
+		t.Fatalf("expected current file to hold only the post-rotation write, got %q", data)
+	}
+}
+
+func TestUniqueBackupPathAvoidsCollision(t *testing.T) {
+	w := newTestRotatingWriter(t, 0, 0, 0)
+
+	first := w.uniqueBackupPath()
+	if err := os.WriteFile(first, []byte("x"), 0644); err != nil {
+		t.Fatalf("seed existing backup: %v", err)
+	}
+
+	second := w.uniqueBackupPath()
+	if second == first {
+		t.Fatalf("uniqueBackupPath returned a colliding name twice: %q", second)
+	}
+	if _, err := os.Stat(second); !os.IsNotExist(err) {
+		t.Fatalf("uniqueBackupPath returned a path that already exists: %q", second)
+	}
+}
+
+func TestPruneRemovesAgedAndExcessBackups(t *testing.T) {
+	w := newTestRotatingWriter(t, 0, time.Hour, 2)
+
+	dir := filepath.Dir(w.path)
+	base := filepath.Base(w.path)
+
+	makeBackup := func(suffix string, age time.Duration) string {
+		p := filepath.Join(dir, base+"."+suffix)
+		if err := os.WriteFile(p, []byte("x"), 0644); err != nil {
+			t.Fatalf("write backup %s: %v", suffix, err)
+		}
+		stamp := time.Now().Add(-age)
+		if err := os.Chtimes(p, stamp, stamp); err != nil {
+			t.Fatalf("chtimes %s: %v", suffix, err)
+		}
+		return p
+	}
+
+	aged := makeBackup("2020-01-01T00-00-00", 48*time.Hour)
+	recent1 := makeBackup("2020-01-02T00-00-00", time.Minute)
+	recent2 := makeBackup("2020-01-03T00-00-00", time.Minute)
+	recent3 := makeBackup("2020-01-04T00-00-00", time.Minute)
+
+	w.prune()
+
+	assertRemoved := func(p string) {
+		if _, err := os.Stat(p); !os.IsNotExist(err) {
+			t.Errorf("expected %s to be pruned", p)
+		}
+	}
+	assertKept := func(p string) {
+		if _, err := os.Stat(p); err != nil {
+			t.Errorf("expected %s to survive pruning: %v", p, err)
+		}
+	}
+
+	assertRemoved(aged)     // older than maxAge
+	assertRemoved(recent1)  // within maxAge but beyond maxBackups once aged is gone
+	assertKept(recent2)
+	assertKept(recent3)
+}
@@ -0,0 +1,165 @@
+package logger
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"runtime"
+)
+
+// SlogHandler adapts a systemConfig to the standard library's log/slog.Handler
+// interface so callers that standardize on slog can still route records through
+// this module's output pipeline, level gating, subsystem fields, and JSON/text
+// formatting.
+type SlogHandler struct {
+	config      *systemConfig
+	groupPrefix string
+}
+
+// newSlogHandler creates a slog.Handler backed by an existing systemConfig.
+// Unexported because systemConfig is unexported; external callers go through
+// NewSlogHandlerFromSetup instead. Takes a pointer so SlogHandler never
+// copies systemConfig's sync.Mutex.
+func newSlogHandler(config *systemConfig) *SlogHandler {
+	return &SlogHandler{config: config}
+}
+
+// NewSlogHandlerFromSetup creates a slog.Handler from a SetupConfig, using the
+// same construction path as the rest of the package.
+func NewSlogHandlerFromSetup(setup SetupConfig) (*SlogHandler, error) {
+	config, err := newSystemConfigFromSetup(setup)
+	if err != nil {
+		return nil, err
+	}
+
+	return newSlogHandler(&config), nil
+}
+
+// Enabled implements slog.Handler, routing through minLevelFor so a
+// subsystem override set via addSubSystem/SetSubSystemLevel applies to slog
+// gating the same way it does on the Log.*-style write path.
+func (h *SlogHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return h.config.minLevelFor() <= slogToLevel(level)
+}
+
+// Handle implements slog.Handler. It never exits or panics the process --
+// slog.Log(ctx, level, ...) accepts arbitrary caller-supplied levels, so
+// routing slogToLevel's result through writeEntry's Fatal/Panic handling
+// would let any slog call above LevelError kill the process from inside a
+// Handler. slogToLevel clamps to LevelError for exactly this reason.
+func (h *SlogHandler) Handle(ctx context.Context, record slog.Record) error {
+	level := slogToLevel(record.Level)
+	if h.config.minLevelFor() > level {
+		return nil // Level is below minimum
+	}
+
+	fields := make([]Field, 0, record.NumAttrs())
+	record.Attrs(func(attr slog.Attr) bool {
+		fields = append(fields, attrToFields(h.groupPrefix, attr)...)
+		return true
+	})
+
+	return h.config.writeEntry(level, slogCaller(record.PC), fields, "%s", record.Message)
+}
+
+// WithAttrs implements slog.Handler by cloning the underlying systemConfig and
+// appending the attrs as fields under the current group prefix.
+func (h *SlogHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	newConfig := h.config.Copy()
+	for _, attr := range attrs {
+		for _, field := range attrToFields(h.groupPrefix, attr) {
+			newConfig.addField(field)
+		}
+	}
+
+	return &SlogHandler{config: newConfig, groupPrefix: h.groupPrefix}
+}
+
+// WithGroup implements slog.Handler by cloning the underlying systemConfig and
+// qualifying subsequent field names with the group name.
+func (h *SlogHandler) WithGroup(name string) slog.Handler {
+	prefix := name
+	if len(h.groupPrefix) > 0 {
+		prefix = h.groupPrefix + "." + name
+	}
+
+	return &SlogHandler{config: h.config.Copy(), groupPrefix: prefix}
+}
+
+// slogCaller resolves a slog.Record's program counter into a "file:line" string,
+// matching the caller format already produced for IncludeCaller.
+func slogCaller(pc uintptr) string {
+	if pc == 0 {
+		return ""
+	}
+
+	frames := runtime.CallersFrames([]uintptr{pc})
+	frame, _ := frames.Next()
+	if frame.File == "" {
+		return ""
+	}
+
+	return fmt.Sprintf("%s:%d", frame.File, frame.Line)
+}
+
+// attrToFields flattens a slog.Attr into one or more Fields, recursing into
+// nested Groups and qualifying names with prefix.
+func attrToFields(prefix string, attr slog.Attr) []Field {
+	value := attr.Value.Resolve()
+	name := attr.Key
+	if len(prefix) > 0 {
+		name = prefix + "." + name
+	}
+
+	if value.Kind() == slog.KindGroup {
+		var fields []Field
+		for _, groupAttr := range value.Group() {
+			fields = append(fields, attrToFields(name, groupAttr)...)
+		}
+		return fields
+	}
+
+	return []Field{attrToField(name, value)}
+}
+
+// attrToField converts a single resolved slog.Value into this module's Field type.
+func attrToField(name string, value slog.Value) Field {
+	switch value.Kind() {
+	case slog.KindBool:
+		return Bool(name, value.Bool())
+	case slog.KindInt64:
+		return Int64(name, value.Int64())
+	case slog.KindUint64:
+		return Uint64(name, value.Uint64())
+	case slog.KindFloat64:
+		return Float64(name, value.Float64())
+	case slog.KindDuration:
+		return Duration(name, value.Duration())
+	case slog.KindTime:
+		return Time(name, value.Time())
+	default:
+		if err, ok := value.Any().(error); ok {
+			return Error(name, err)
+		}
+		return String(name, value.String())
+	}
+}
+
+// slogToLevel maps a slog.Level onto this module's Level, preserving the
+// relative ordering of slog's four named levels (Debug, Info, Warn, Error)
+// while clamping to the range this package supports. The top end clamps to
+// LevelError rather than LevelFatal/LevelPanic: slog.Log accepts arbitrary
+// caller-chosen levels, and Handle must never let one of those silently
+// os.Exit or panic the process.
+func slogToLevel(level slog.Level) Level {
+	result := LevelInfo + Level(int(level)/4)
+
+	if result < LevelDebug {
+		return LevelDebug
+	}
+	if result > LevelError {
+		return LevelError
+	}
+
+	return result
+}
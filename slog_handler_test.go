@@ -0,0 +1,85 @@
+package logger
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+)
+
+func newTestSlogConfig(minLevel Level, output io.Writer) *systemConfig {
+	return &systemConfig{minLevel: minLevel, output: output, format: IncludeLevel}
+}
+
+func TestSlogToLevelNeverReachesFatalOrPanic(t *testing.T) {
+	for _, level := range []slog.Level{slog.LevelError, 12, 100} {
+		if result := slogToLevel(level); result == LevelFatal || result == LevelPanic {
+			t.Fatalf("slogToLevel(%d) = %v, must never reach a level that exits or panics the process", level, result)
+		}
+	}
+}
+
+func TestSlogToLevelPreservesNamedLevelOrdering(t *testing.T) {
+	debug := slogToLevel(slog.LevelDebug)
+	info := slogToLevel(slog.LevelInfo)
+	warn := slogToLevel(slog.LevelWarn)
+	errLevel := slogToLevel(slog.LevelError)
+
+	if !(debug < info && info < warn && warn < errLevel) {
+		t.Fatalf("expected strictly increasing levels, got debug=%v info=%v warn=%v error=%v", debug, info, warn, errLevel)
+	}
+}
+
+func TestSlogHandlerEnabledUsesSubsystemOverride(t *testing.T) {
+	var buf bytes.Buffer
+	config := newTestSlogConfig(LevelError, &buf)
+	config.SetSubSystemLevel("", LevelDebug)
+	config.addSubSystem("worker")
+
+	h := newSlogHandler(config)
+
+	if !h.Enabled(context.Background(), slog.LevelDebug) {
+		t.Fatal("expected Enabled to honor the subsystem's wildcard override, not config.minLevel")
+	}
+}
+
+func TestSlogHandlerNeverPanicsOnOutOfRangeLevel(t *testing.T) {
+	var buf bytes.Buffer
+	config := newTestSlogConfig(LevelDebug, &buf)
+	h := newSlogHandler(config)
+
+	record := slog.NewRecord(time.Now(), slog.Level(100), "boom", 0)
+
+	defer func() {
+		if r := recover(); r != nil {
+			t.Fatalf("Handle must not panic for an out-of-range slog.Level, got: %v", r)
+		}
+	}()
+
+	if err := h.Handle(context.Background(), record); err != nil {
+		t.Fatalf("Handle: %v", err)
+	}
+}
+
+func TestSlogHandlerWithGroupQualifiesFieldNames(t *testing.T) {
+	var buf bytes.Buffer
+	config := newTestSlogConfig(LevelDebug, &buf)
+	h, ok := newSlogHandler(config).WithGroup("request").(*SlogHandler)
+	if !ok {
+		t.Fatal("expected WithGroup to return a *SlogHandler")
+	}
+
+	record := slog.NewRecord(time.Now(), slog.LevelInfo, "handled", 0)
+	record.AddAttrs(slog.Int("status", 200))
+
+	if err := h.Handle(context.Background(), record); err != nil {
+		t.Fatalf("Handle: %v", err)
+	}
+
+	if !strings.Contains(buf.String(), "request.status") {
+		t.Fatalf("expected group-qualified field name %q in output, got %q", "request.status", buf.String())
+	}
+}
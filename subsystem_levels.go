@@ -0,0 +1,66 @@
+package logger
+
+// subSystemWildcard is the key used for the default level applied to
+// subsystems with no explicit entry.
+const subSystemWildcard = "*"
+
+// SetSubSystemLevel sets the minimum log level for entries tagged with the
+// given subsystem name on this config alone, overriding minLevel. Pass "" to
+// set the wildcard/default level applied to subsystems with no explicit
+// entry. Scoped to config so unrelated loggers never see each other's
+// overrides.
+func (config *systemConfig) SetSubSystemLevel(name string, level Level) {
+	if name == "" {
+		name = subSystemWildcard
+	}
+
+	current, _ := config.subSystemLevels.Load().(map[string]Level)
+	next := make(map[string]Level, len(current)+1)
+	for k, v := range current {
+		next[k] = v
+	}
+	next[name] = level
+
+	config.subSystemLevels.Store(next)
+}
+
+// SetSubSystemLevels replaces this config's full per-subsystem level table,
+// as loaded from SetupConfig.SubSystemLevels.
+func (config *systemConfig) SetSubSystemLevels(levels map[string]Level) {
+	next := make(map[string]Level, len(levels))
+	for name, level := range levels {
+		next[name] = level
+	}
+
+	config.subSystemLevels.Store(next)
+}
+
+// subSystemLevel looks up the effective minimum level for a subsystem name in
+// config's own table, falling back to the wildcard entry and then to
+// fallback (the config's own minLevel) when neither is set.
+func (config *systemConfig) subSystemLevel(name string, fallback Level) Level {
+	levels, _ := config.subSystemLevels.Load().(map[string]Level)
+
+	if level, ok := levels[name]; ok {
+		return level
+	}
+	if level, ok := levels[subSystemWildcard]; ok {
+		return level
+	}
+
+	return fallback
+}
+
+// minLevelFor returns the effective minimum level for config. The subsystem
+// name is snapshotted into config.subSystemName by addSubSystem/
+// removeSubSystem rather than parsed out of config.fields here, so the hot
+// path is atomic loads and map lookups with no lock and no allocation.
+func (config *systemConfig) minLevelFor() Level {
+	name, _ := config.subSystemName.Load().(string)
+
+	if name == "" {
+		return config.minLevel
+	}
+
+	return config.subSystemLevel(name, config.minLevel)
+}
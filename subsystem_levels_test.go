@@ -0,0 +1,57 @@
+package logger
+
+import "testing"
+
+func TestSubSystemLevelWildcardAndOverride(t *testing.T) {
+	config := &systemConfig{minLevel: LevelError}
+
+	if got := config.subSystemLevel("worker", config.minLevel); got != LevelError {
+		t.Fatalf("expected fallback to minLevel with no overrides, got %v", got)
+	}
+
+	config.SetSubSystemLevel("", LevelDebug)
+	if got := config.subSystemLevel("worker", config.minLevel); got != LevelDebug {
+		t.Fatalf("expected the wildcard override to apply to an unlisted subsystem, got %v", got)
+	}
+
+	config.SetSubSystemLevel("worker", LevelWarn)
+	if got := config.subSystemLevel("worker", config.minLevel); got != LevelWarn {
+		t.Fatalf("expected the specific subsystem override to take precedence over the wildcard, got %v", got)
+	}
+	if got := config.subSystemLevel("other", config.minLevel); got != LevelDebug {
+		t.Fatalf("expected an unrelated subsystem to still fall back to the wildcard, got %v", got)
+	}
+}
+
+func TestMinLevelForUsesSnapshottedSubsystemName(t *testing.T) {
+	config := &systemConfig{minLevel: LevelError}
+	config.SetSubSystemLevel("worker", LevelDebug)
+
+	if got := config.minLevelFor(); got != LevelError {
+		t.Fatalf("expected minLevelFor to use config.minLevel before addSubSystem snapshots a name, got %v", got)
+	}
+
+	config.addSubSystem("worker")
+	if got := config.minLevelFor(); got != LevelDebug {
+		t.Fatalf("expected minLevelFor to use the worker override once addSubSystem snapshots its name, got %v", got)
+	}
+
+	config.removeSubSystem()
+	if got := config.minLevelFor(); got != LevelError {
+		t.Fatalf("expected minLevelFor to fall back to minLevel after removeSubSystem clears the snapshot, got %v", got)
+	}
+}
+
+func TestSetSubSystemLevelsReplacesWholeTable(t *testing.T) {
+	config := &systemConfig{minLevel: LevelError}
+	config.SetSubSystemLevel("worker", LevelDebug)
+
+	config.SetSubSystemLevels(map[string]Level{"api": LevelWarn})
+
+	if got := config.subSystemLevel("worker", config.minLevel); got != LevelError {
+		t.Fatalf("expected SetSubSystemLevels to replace the prior table, but worker's override survived: %v", got)
+	}
+	if got := config.subSystemLevel("api", config.minLevel); got != LevelWarn {
+		t.Fatalf("expected the new table's api override to apply, got %v", got)
+	}
+}
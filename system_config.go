@@ -7,6 +7,7 @@ import (
 	"os"
 	"strconv"
 	"sync"
+	"sync/atomic"
 	"time"
 )
 
@@ -20,12 +21,6 @@ var (
 		"fatal",
 		"panic",
 	}
-
-	tab               = []byte{byte('\t')}
-	comma             = []byte{byte(',')}
-	newLine           = []byte{byte('\n')}
-	openCurly         = []byte{byte('{')}
-	closeCurlyNewLine = []byte{byte('}'), byte('\n')}
 )
 
 const (
@@ -43,18 +38,52 @@ type systemConfig struct {
 	fields     []Field
 	format     int
 
+	// subSystemName is a lock-free snapshot of the "subsystem" field's
+	// value (kept in sync by addSubSystem/removeSubSystem) so minLevelFor
+	// can check it on every writeEntry call without taking lock or parsing
+	// config.fields.
+	subSystemName atomic.Value // string
+
+	// subSystemLevels holds this config's own per-subsystem minimum level
+	// table, set via SetSubSystemLevel/SetSubSystemLevels. It is scoped to
+	// this config so separate loggers never clobber each other's overrides.
+	subSystemLevels atomic.Value // map[string]Level
+
 	lock sync.Mutex
 }
 
-// Copy makes a separate copy so if the fields are modified in one copy they will not be in another.
-func (config systemConfig) Copy() systemConfig {
-	result := config
-
+// Copy makes a separate copy so if the fields are modified in one copy they
+// will not be in another. Returns *systemConfig (and takes a pointer
+// receiver) so callers never copy config's sync.Mutex; the copy gets its own
+// zero-value lock and its own duplicated subsystem name/level state instead
+// of sharing config's.
+func (config *systemConfig) Copy() *systemConfig {
 	config.lock.Lock()
-	result.fields = make([]Field, len(config.fields))
-	copy(result.fields, config.fields)
+	fields := make([]Field, len(config.fields))
+	copy(fields, config.fields)
 	config.lock.Unlock()
 
+	result := &systemConfig{
+		minLevel:   config.minLevel,
+		stackLevel: config.stackLevel,
+		isText:     config.isText,
+		output:     config.output,
+		fields:     fields,
+		format:     config.format,
+	}
+
+	if name, ok := config.subSystemName.Load().(string); ok {
+		result.subSystemName.Store(name)
+	}
+
+	if levels, ok := config.subSystemLevels.Load().(map[string]Level); ok {
+		next := make(map[string]Level, len(levels))
+		for k, v := range levels {
+			next[k] = v
+		}
+		result.subSystemLevels.Store(next)
+	}
+
 	return result
 }
 
@@ -103,7 +132,11 @@ func newSystemConfigFromSetup(setup SetupConfig) (systemConfig, error) {
 
 	result.minLevel = setup.Level
 
-	output, err := newOutput(setup.Path)
+	if len(setup.SubSystemLevels) > 0 {
+		result.SetSubSystemLevels(setup.SubSystemLevels)
+	}
+
+	output, err := newOutputFromSetup(setup)
 	if err != nil {
 		return result, err
 	}
@@ -144,11 +177,14 @@ func (config *systemConfig) addField(newField Field) {
 	config.fields = append(config.fields, newField)
 }
 
-// addSubSystem adds a subsystem to the log outputs
+// addSubSystem adds a subsystem to the log outputs, also snapshotting its
+// name into config.subSystemName so minLevelFor can read it lock-free.
 func (config *systemConfig) addSubSystem(name string) {
 	config.lock.Lock()
 	defer config.lock.Unlock()
 
+	config.subSystemName.Store(name)
+
 	for i, field := range config.fields {
 		if field.Name() == "subsystem" {
 			config.fields[i] = String("subsystem", name)
@@ -159,11 +195,14 @@ func (config *systemConfig) addSubSystem(name string) {
 	config.fields = append(config.fields, String("subsystem", name))
 }
 
-// removeSubSystem removes the subsystem from the log outputs
+// removeSubSystem removes the subsystem from the log outputs and clears the
+// subSystemName snapshot.
 func (config *systemConfig) removeSubSystem() {
 	config.lock.Lock()
 	defer config.lock.Unlock()
 
+	config.subSystemName.Store("")
+
 	for i, field := range config.fields {
 		if field.Name() == "subsystem" {
 			config.fields = append(config.fields[:i], config.fields[i+1:]...)
@@ -175,56 +214,203 @@ func (config *systemConfig) removeSubSystem() {
 func (config *systemConfig) writeEntry(level Level, caller string, fields []Field,
 	format string, values ...interface{}) error {
 
+	if config.output == nil {
+		return nil
+	}
+
+	if config.minLevelFor() > level {
+		return nil // Level is below minimum
+	}
+
+	msg := format
+	if len(values) > 0 {
+		msg = fmt.Sprintf(format, values...)
+	}
+
+	var err error
 	if config.isText {
-		return config.writeTextEntry(level, caller, fields, format, values...)
+		err = config.writeTextEntry(level, caller, fields, msg)
+	} else {
+		err = config.writeJSONEntry(level, caller, fields, msg)
+	}
+
+	switch level {
+	case LevelFatal:
+		defer os.Exit(1)
+	case LevelPanic:
+		defer panic(msg)
 	}
 
-	return config.writeJSONEntry(level, caller, fields, format, values...)
+	return err
+}
+
+// entryPool holds the *entry (and its *bytes.Buffer) each log call builds
+// its record in, so neither the entry itself nor its buffer is allocated
+// per call.
+var entryPool = sync.Pool{
+	New: func() interface{} { return &entry{buf: new(bytes.Buffer)} },
 }
 
+// entry accumulates one log record into a pooled buffer so the whole record
+// is emitted with a single output.Write and no intermediate []byte
+// allocations per field.
 type entry struct {
 	isJSON    bool
-	delimiter []byte
-	values    [][]byte
+	delimiter byte
+	buf       *bytes.Buffer
+	wrote     bool
 }
 
-func (e *entry) writeField(format string, values ...interface{}) {
-	e.values = append(e.values, []byte(fmt.Sprintf(format, values...)))
+func newEntry(isJSON bool, delimiter byte) *entry {
+	e := entryPool.Get().(*entry)
+	e.isJSON = isJSON
+	e.delimiter = delimiter
+	e.wrote = false
+	e.buf.Reset()
+
+	if isJSON {
+		e.buf.WriteByte('{')
+	}
+
+	return e
 }
 
-func (e *entry) addValue(value []byte) {
-	e.values = append(e.values, value)
+// appendSeparator writes the field delimiter before every field after the
+// first.
+func (e *entry) appendSeparator() {
+	if e.wrote {
+		e.buf.WriteByte(e.delimiter)
+	}
+	e.wrote = true
+}
+
+// appendToken appends a bare value with no key, the form used by the text
+// encoder for level, timestamp, date/time, caller, and message.
+func (e *entry) appendToken(value string) {
+	e.appendSeparator()
+
+	b := e.buf.AvailableBuffer()
+	b = append(b, value...)
+	e.buf.Write(b)
+}
+
+// appendTokenBytes is appendToken for a value that's already a []byte, so
+// callers building it with strconv's Append* family don't have to convert
+// it to a string (and allocate) first.
+func (e *entry) appendTokenBytes(value []byte) {
+	e.appendSeparator()
+
+	b := e.buf.AvailableBuffer()
+	b = append(b, value...)
+	e.buf.Write(b)
+}
+
+// appendJSONString appends a `"name":"value"` pair, quoting value with
+// strconv.AppendQuote.
+func (e *entry) appendJSONString(name, value string) {
+	e.appendSeparator()
+
+	b := e.buf.AvailableBuffer()
+	b = append(b, '"')
+	b = append(b, name...)
+	b = append(b, '"', ':')
+	b = strconv.AppendQuote(b, value)
+	e.buf.Write(b)
+}
+
+// appendJSONRaw appends a `"name":value` pair where value is already
+// encoded, e.g. a number.
+func (e *entry) appendJSONRaw(name string, value []byte) {
+	e.appendSeparator()
+
+	b := e.buf.AvailableBuffer()
+	b = append(b, '"')
+	b = append(b, name...)
+	b = append(b, '"', ':')
+	b = append(b, value...)
+	e.buf.Write(b)
+}
+
+// appendField appends a Field using its AppendJSON/AppendText method, in
+// place, with no intermediate allocation from ValueJSON.
+func (e *entry) appendField(field Field) {
+	e.appendSeparator()
+
+	b := e.buf.AvailableBuffer()
+	if e.isJSON {
+		b = field.AppendJSON(b)
+	} else {
+		b = field.AppendText(b)
+	}
+	e.buf.Write(b)
 }
 
+// write emits the finished record to w and returns the entry to the pool.
 func (e *entry) write(w io.Writer) error {
-	b := bytes.Join(e.values, e.delimiter)
 	if e.isJSON {
-		b = append(openCurly, b...)
-		b = append(b, closeCurlyNewLine...)
+		e.buf.WriteByte('}')
 	}
-	_, err := w.Write(b)
+	e.buf.WriteByte('\n')
+
+	_, err := w.Write(e.buf.Bytes())
+
+	entryPool.Put(e)
+
 	return err
 }
 
-func (config *systemConfig) writeJSONEntry(level Level, caller string, fields []Field,
-	format string, values ...interface{}) error {
-
-	if config.output == nil {
-		return nil
+// appendDateTimeLayout reports the AppendFormat layout for the date/time
+// portion of config.format (IncludeDate/IncludeTime, never the fractional
+// seconds, which AppendFormat can't render with the space separator the
+// JSON encoder uses), the JSON field name it belongs under, and whether
+// IncludeMicro also asks for a trailing microsecond count.
+func appendDateTimeLayout(format int) (layout string, name string, hasMicro bool) {
+	micro := format&IncludeMicro != 0
+
+	switch {
+	case format&IncludeDate != 0 && format&IncludeTime != 0:
+		return "2006/01/02 15:04:05", "datetime", micro
+	case format&IncludeDate != 0:
+		return "2006/01/02", "date", false
+	case format&IncludeTime != 0:
+		return "15:04:05", "time", micro
+	default:
+		return "", "", false
 	}
+}
 
-	if config.minLevel > level {
-		return nil // Level is below minimum
+// textDateTimeLayout is appendDateTimeLayout's text-encoder counterpart: the
+// text encoder has always folded the microseconds into the layout itself,
+// dot-separated, rather than appending them with a space like JSON does.
+func textDateTimeLayout(format int) string {
+	switch {
+	case format&IncludeDate != 0 && format&IncludeTime != 0 && format&IncludeMicro != 0:
+		return "2006/01/02 15:04:05.000000"
+	case format&IncludeDate != 0 && format&IncludeTime != 0:
+		return "2006/01/02 15:04:05"
+	case format&IncludeDate != 0:
+		return "2006/01/02"
+	case format&IncludeTime != 0 && format&IncludeMicro != 0:
+		return "15:04:05.000000"
+	case format&IncludeTime != 0:
+		return "15:04:05"
+	default:
+		return ""
 	}
+}
 
-	entry := entry{
-		isJSON:    true,
-		delimiter: comma,
-	}
+// writeJSONEntry encodes one JSON log record using the already-formatted
+// msg. Callers are responsible for level gating and Fatal/Panic handling
+// (see writeEntry and MultiSink.writeEntry), so the message is only
+// formatted once even when fanned out to multiple sinks.
+func (config *systemConfig) writeJSONEntry(level Level, caller string, fields []Field,
+	msg string) error {
+
+	e := newEntry(true, ',')
 
 	// Write Level
 	if config.format&IncludeLevel != 0 {
-		entry.writeField("\"level\":\"%s\"", levelName[level+levelOffset])
+		e.appendJSONString("level", levelName[level+levelOffset])
 	}
 
 	// Create log entry
@@ -232,54 +418,43 @@ func (config *systemConfig) writeJSONEntry(level Level, caller string, fields []
 
 	// Append timestamp
 	if config.format&IncludeTimeStamp != 0 {
-		entry.writeField("\"ts\":%d.%06d", now.Unix(), now.Nanosecond()/1e3)
-	}
-
-	// Append Date
-	var datetime bytes.Buffer
-	if config.format&IncludeDate != 0 {
-		year, month, day := now.Date()
-		fmt.Fprintf(&datetime, "%04d/%02d/%02d", year, month, day)
-		if config.format&IncludeTime != 0 {
-			fmt.Fprint(&datetime, []byte(" "))
+		var scratch [32]byte
+		b := strconv.AppendInt(scratch[:0], now.Unix(), 10)
+		b = append(b, '.')
+		b = appendPadded(b, now.Nanosecond()/1e3, 6)
+		e.appendJSONRaw("ts", b)
+	}
+
+	// Append Date/Time. The JSON encoder has always separated the date/time
+	// from the microseconds with a space (unlike the text encoder's dot),
+	// so the microseconds are appended by hand rather than folded into the
+	// AppendFormat layout.
+	if layout, name, hasMicro := appendDateTimeLayout(config.format); len(layout) > 0 {
+		var scratch [40]byte
+		b := append(scratch[:0], '"')
+		b = now.AppendFormat(b, layout)
+		if hasMicro {
+			b = append(b, ' ')
+			b = appendPadded(b, now.Nanosecond()/1e3, 6)
 		}
-	}
-
-	// Append Time
-	if config.format&IncludeTime != 0 {
-		hour, min, sec := now.Clock()
-		fmt.Fprintf(&datetime, "%02d:%02d:%02d", hour, min, sec)
-		if config.format&IncludeMicro != 0 {
-			fmt.Fprintf(&datetime, " %06d", now.Nanosecond()/1e3)
-		}
-	}
-
-	if datetime.Len() > 0 {
-		name := ""
-		if config.format&IncludeDate != 0 {
-			name = "date"
-		}
-		if config.format&IncludeTime != 0 {
-			name += "time"
-		}
-
-		entry.writeField("\"%s\":\"%s\"", name, string(datetime.Bytes()))
+		b = append(b, '"')
+		e.appendJSONRaw(name, b)
 	}
 
 	// Append Caller
 	if config.format&IncludeCaller != 0 {
-		entry.writeField("\"caller\":%s", strconv.Quote(caller))
+		e.appendJSONString("caller", caller)
 	}
 
 	// Append actual log entry
-	entry.writeField("\"msg\":%s", strconv.Quote(fmt.Sprintf(format, values...)))
+	e.appendJSONString("msg", msg)
 
 	config.lock.Lock()
 	for i, field := range config.fields {
 		if fieldExists(field.Name(), config.fields[:i]) {
 			continue // skip duplicate field name
 		}
-		entry.writeField("\"%s\":%s", field.Name(), field.ValueJSON())
+		e.appendField(field)
 	}
 	config.lock.Unlock()
 
@@ -287,40 +462,22 @@ func (config *systemConfig) writeJSONEntry(level Level, caller string, fields []
 		if fieldExists(field.Name(), config.fields) || fieldExists(field.Name(), fields[:i]) {
 			continue // skip duplicate field name
 		}
-		entry.writeField("\"%s\":%s", field.Name(), field.ValueJSON())
-	}
-
-	entry.write(config.output)
-
-	switch level {
-	case LevelFatal:
-		defer os.Exit(1)
-	case LevelPanic:
-		defer panic(fmt.Sprintf(format, values...))
+		e.appendField(field)
 	}
 
-	return nil
+	return e.write(config.output)
 }
 
+// writeTextEntry encodes one text log record using the already-formatted
+// msg; see writeJSONEntry for why msg is passed in pre-formatted.
 func (config *systemConfig) writeTextEntry(level Level, caller string, fields []Field,
-	format string, values ...interface{}) error {
-
-	if config.output == nil {
-		return nil
-	}
+	msg string) error {
 
-	if config.minLevel > level {
-		return nil // Level is below minimum
-	}
-
-	// Write full entry to output
-	entry := entry{
-		delimiter: tab,
-	}
+	e := newEntry(false, '\t')
 
 	// Write Level
 	if config.format&IncludeLevel != 0 {
-		entry.writeField("%s", levelName[level+levelOffset])
+		e.appendToken(levelName[level+levelOffset])
 	}
 
 	// Create log entry
@@ -328,46 +485,36 @@ func (config *systemConfig) writeTextEntry(level Level, caller string, fields []
 
 	// Append timestamp
 	if config.format&IncludeTimeStamp != 0 {
-		entry.writeField("ts %d.%06d", now.Unix(), now.Nanosecond()/1e3)
+		var scratch [32]byte
+		b := append(scratch[:0], "ts "...)
+		b = strconv.AppendInt(b, now.Unix(), 10)
+		b = append(b, '.')
+		b = appendPadded(b, now.Nanosecond()/1e3, 6)
+		e.appendTokenBytes(b)
 	}
 
-	// Append Date
-	var datetime bytes.Buffer
-	if config.format&IncludeDate != 0 {
-		year, month, day := now.Date()
-		fmt.Fprintf(&datetime, "%04d/%02d/%02d", year, month, day)
-		if config.format&IncludeTime != 0 {
-			fmt.Fprint(&datetime, []byte(" "))
-		}
-	}
-
-	// Append Time
-	if config.format&IncludeTime != 0 {
-		hour, min, sec := now.Clock()
-		fmt.Fprintf(&datetime, "%02d:%02d:%02d", hour, min, sec)
-		if config.format&IncludeMicro != 0 {
-			fmt.Fprintf(&datetime, ".%06d", now.Nanosecond()/1e3)
-		}
-	}
-
-	if datetime.Len() > 0 {
-		entry.writeField("%s", string(datetime.Bytes()))
+	// Append Date/Time. Unlike the JSON encoder, text has always used a dot
+	// before the microseconds, so it can fold straight into the layout.
+	if layout := textDateTimeLayout(config.format); len(layout) > 0 {
+		var scratch [40]byte
+		b := now.AppendFormat(scratch[:0], layout)
+		e.appendTokenBytes(b)
 	}
 
 	// Append Caller
 	if config.format&IncludeCaller != 0 {
-		entry.writeField(caller)
+		e.appendToken(caller)
 	}
 
 	// Append actual log entry
-	entry.writeField("%s", fmt.Sprintf(format, values...))
+	e.appendToken(msg)
 
 	config.lock.Lock()
 	for i, field := range config.fields {
 		if fieldExists(field.Name(), config.fields[:i]) {
 			continue // skip duplicate field name
 		}
-		entry.writeField("%s: %s", field.Name(), field.ValueJSON())
+		e.appendField(field)
 	}
 	config.lock.Unlock()
 
@@ -375,20 +522,30 @@ func (config *systemConfig) writeTextEntry(level Level, caller string, fields []
 		if fieldExists(field.Name(), config.fields) || fieldExists(field.Name(), fields[:i]) {
 			continue // skip duplicate field name
 		}
-		entry.writeField("%s: %s", field.Name(), field.ValueJSON())
+		e.appendField(field)
 	}
 
-	entry.addValue(newLine)
-	entry.write(config.output)
+	return e.write(config.output)
+}
 
-	switch level {
-	case LevelFatal:
-		defer os.Exit(1)
-	case LevelPanic:
-		defer panic(fmt.Sprintf(format, values...))
+// appendPadded appends n to dst zero-padded to width digits, matching the
+// microsecond formatting the Sprintf-based encoder used to produce.
+func appendPadded(dst []byte, n, width int) []byte {
+	start := len(dst)
+	dst = strconv.AppendInt(dst, int64(n), 10)
+	digits := len(dst) - start
+
+	if digits >= width {
+		return dst
+	}
+
+	dst = append(dst, make([]byte, width-digits)...)
+	copy(dst[start+width-digits:], dst[start:start+digits])
+	for i := 0; i < width-digits; i++ {
+		dst[start+i] = '0'
 	}
 
-	return nil
+	return dst
 }
 
 func fieldExists(name string, fields []Field) bool {
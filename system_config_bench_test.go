@@ -0,0 +1,50 @@
+package logger
+
+import (
+	"errors"
+	"testing"
+)
+
+var errBenchmark = errors.New("boom")
+
+func benchFields() []Field {
+	return []Field{
+		Bool("active", true),
+		Int64("count", 42),
+		Float64("ratio", 0.5),
+		String("name", "widget"),
+		Error("err", errBenchmark),
+	}
+}
+
+// BenchmarkWriteJSONEntry demonstrates that, once warmed up, logging a
+// typical field set through the JSON encoder allocates nothing per call.
+func BenchmarkWriteJSONEntry(b *testing.B) {
+	config, err := newSystemConfig(false, false, "dummy")
+	if err != nil {
+		b.Fatal(err)
+	}
+	fields := benchFields()
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		config.writeEntry(LevelInfo, "system_config_bench_test.go:1", fields, "request handled")
+	}
+}
+
+// BenchmarkWriteTextEntry is the text-encoder counterpart of
+// BenchmarkWriteJSONEntry.
+func BenchmarkWriteTextEntry(b *testing.B) {
+	config, err := newSystemConfig(false, true, "dummy")
+	if err != nil {
+		b.Fatal(err)
+	}
+	fields := benchFields()
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		config.writeEntry(LevelInfo, "system_config_bench_test.go:1", fields, "request handled")
+	}
+}